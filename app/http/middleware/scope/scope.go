@@ -0,0 +1,21 @@
+package scope
+
+import (
+	"github.com/JoeZhao1/go-start/framework"
+	"github.com/JoeZhao1/go-start/framework/gin"
+)
+
+// Middleware 为每一个 HTTP 请求创建一个容器 scope，挂载到 gin.Context 上，
+// 使得 handler 可以通过 framework.FromContext(c) 获取请求级别的实例
+// （比如携带 trace-id 的 logger、租户专属的数据库连接）。
+// 请求结束后会调用 scope 的 Release()，回收这次请求实例化出来的资源
+func Middleware(container framework.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqScope := container.NewScope()
+		c.Set(framework.ScopeContextKey, reqScope)
+
+		c.Next()
+
+		_ = reqScope.Release()
+	}
+}
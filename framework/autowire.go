@@ -0,0 +1,228 @@
+package framework
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// containerTag 是结构体参数构造函数中，用于声明某个字段应该按字符串凭证而非类型解析的 tag
+const containerTag = "container"
+
+// typeBinding 记录一个通过 Provide/Singleton/Prototype 注册的构造函数
+type typeBinding struct {
+	// constructor 是用户传入的构造函数
+	constructor reflect.Value
+	// isSingleton 为 true 时，构造函数只会被调用一次，后续复用同一个实例
+	isSingleton bool
+	// once 保证单例模式下构造函数在并发场景下也只被调用一次，
+	// 避免两个并发的首次 Resolve 都通过了检查从而各自构造出一个实例
+	once sync.Once
+	// instance 缓存单例模式下已经构造出来的实例
+	instance reflect.Value
+	// err 缓存单例构造失败时的错误，构造只会尝试一次，重复解析会复用这个错误
+	err error
+}
+
+// Provide 等价于 Singleton，注册一个按类型自动装配的构造函数
+func (start *StartContainer) Provide(fn interface{}) error {
+	return start.provide(fn, true)
+}
+
+// Singleton 注册一个构造函数，它产出的实例只会被构造一次，后续按类型解析时直接复用
+func (start *StartContainer) Singleton(fn interface{}) error {
+	return start.provide(fn, true)
+}
+
+// Prototype 注册一个构造函数，它产出的实例每次按类型解析时都会重新构造
+func (start *StartContainer) Prototype(fn interface{}) error {
+	return start.provide(fn, false)
+}
+
+// provide 将构造函数按照其返回值类型记录到类型注册表中
+func (start *StartContainer) provide(fn interface{}, singleton bool) error {
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func {
+		return fmt.Errorf("framework: Provide 需要传入一个函数，实际传入 %s", fnVal.Kind())
+	}
+	fnType := fnVal.Type()
+	if fnType.NumOut() == 0 {
+		return fmt.Errorf("framework: Provide 传入的函数必须至少有一个返回值")
+	}
+
+	start.lock.Lock()
+	defer start.lock.Unlock()
+	if start.typeBindings == nil {
+		start.typeBindings = map[reflect.Type]*typeBinding{}
+	}
+	start.typeBindings[fnType.Out(0)] = &typeBinding{
+		constructor: fnVal,
+		isSingleton: singleton,
+	}
+	return nil
+}
+
+// Resolve 根据 target 指向的类型，自动装配一个实例并赋值给 target，target 必须是一个指针
+func (start *StartContainer) Resolve(target interface{}) error {
+	targetVal := reflect.ValueOf(target)
+	if targetVal.Kind() != reflect.Ptr {
+		return fmt.Errorf("framework: Resolve 需要传入一个指针，实际传入 %s", targetVal.Kind())
+	}
+
+	val, err := start.resolveType(targetVal.Elem().Type(), map[reflect.Type]bool{})
+	if err != nil {
+		return err
+	}
+	targetVal.Elem().Set(val)
+	return nil
+}
+
+// makeByTypeName 是字符串凭证 Make 接口在找不到注册的服务提供者时的兜底路径：
+// 如果类型注册表中存在一个返回值类型名与 key 相同的构造函数，则用它来解析
+func (start *StartContainer) makeByTypeName(key string) (interface{}, error) {
+	typ := start.findTypeByName(key)
+	if typ == nil {
+		return nil, fmt.Errorf("framework: 类型注册表中没有找到和凭证 %s 匹配的类型", key)
+	}
+
+	val, err := start.resolveType(typ, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return val.Interface(), nil
+}
+
+// findTypeByName 在当前容器的类型注册表中查找类型名与 key 相同的类型，
+// 找不到时沿着 parent 链向上查找，使 NewScope 创建的子容器也能按字符串凭证
+// 兜底解析到父容器上通过 Provide/Singleton 注册的类型
+func (start *StartContainer) findTypeByName(key string) reflect.Type {
+	start.lock.RLock()
+	for t := range start.typeBindings {
+		if t.String() == key {
+			start.lock.RUnlock()
+			return t
+		}
+	}
+	parent := start.parent
+	start.lock.RUnlock()
+
+	if parent != nil {
+		return parent.findTypeByName(key)
+	}
+	return nil
+}
+
+// resolveType 解析 typ 对应的实例：优先查找类型注册表中的构造函数并递归装配它的参数，
+// 找不到时回退到字符串凭证注册表（用类型名作为凭证尝试查找）。类型注册表的查找会沿着
+// parent 链向上找，使子容器继承父容器通过 Provide/Singleton 注册的类型；单例的构造和
+// 缓存记在实际持有这个 binding 的容器上，保证同一个单例在所有子容器之间共享
+func (start *StartContainer) resolveType(typ reflect.Type, resolving map[reflect.Type]bool) (reflect.Value, error) {
+	if resolving[typ] {
+		return reflect.Value{}, fmt.Errorf("framework: 检测到循环依赖 %s", typ)
+	}
+
+	binding := start.findTypeBinding(typ)
+	if binding == nil {
+		if sp := start.findServiceProvider(typ.String()); sp != nil {
+			ins, err := start.make(typ.String(), nil, false)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(ins), nil
+		}
+		return reflect.Value{}, fmt.Errorf("framework: 类型 %s 没有被注册，无法自动装配", typ)
+	}
+
+	construct := func() (reflect.Value, error) {
+		resolving[typ] = true
+		args, err := start.resolveArgs(binding.constructor.Type(), resolving)
+		delete(resolving, typ)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		out := binding.constructor.Call(args)
+		instance := out[0]
+		if len(out) > 1 {
+			if errVal, ok := out[len(out)-1].Interface().(error); ok && errVal != nil {
+				return reflect.Value{}, errVal
+			}
+		}
+		return instance, nil
+	}
+
+	if !binding.isSingleton {
+		return construct()
+	}
+
+	// once 保证并发的首次 Resolve 只会有一个真正调用到构造函数，
+	// 其余调用者会阻塞在 Do 上直到构造完成，然后复用同一个实例或同一个错误
+	binding.once.Do(func() {
+		binding.instance, binding.err = construct()
+	})
+	return binding.instance, binding.err
+}
+
+// findTypeBinding 在当前容器的类型注册表中查找 typ 对应的构造函数，
+// 找不到时沿着 parent 链向上查找
+func (start *StartContainer) findTypeBinding(typ reflect.Type) *typeBinding {
+	start.lock.RLock()
+	binding, ok := start.typeBindings[typ]
+	parent := start.parent
+	start.lock.RUnlock()
+
+	if ok {
+		return binding
+	}
+	if parent != nil {
+		return parent.findTypeBinding(typ)
+	}
+	return nil
+}
+
+// resolveArgs 依次解析构造函数的每一个入参
+func (start *StartContainer) resolveArgs(fnType reflect.Type, resolving map[reflect.Type]bool) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		argType := fnType.In(i)
+		if argType.Kind() == reflect.Struct {
+			v, err := start.resolveStructArg(argType, resolving)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+			continue
+		}
+
+		v, err := start.resolveType(argType, resolving)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return args, nil
+}
+
+// resolveStructArg 解析一个结构体参数：字段如果带有 container tag，按字符串凭证从容器中 Make，
+// 否则按字段类型递归自动装配
+func (start *StartContainer) resolveStructArg(typ reflect.Type, resolving map[reflect.Type]bool) (reflect.Value, error) {
+	out := reflect.New(typ).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if key, ok := field.Tag.Lookup(containerTag); ok {
+			ins, err := start.Make(key)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Field(i).Set(reflect.ValueOf(ins))
+			continue
+		}
+
+		v, err := start.resolveType(field.Type, resolving)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Field(i).Set(v)
+	}
+	return out, nil
+}
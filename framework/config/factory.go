@@ -0,0 +1,38 @@
+package config
+
+import "github.com/JoeZhao1/go-start/framework"
+
+// ProviderFactory 根据配置文件中一个 section 的参数，构造出对应的 ServiceProvider。
+// name 是这个 section 在容器中绑定时使用的凭证（比如 "db.master"），
+// params 是这个 section 解析出来的键值对
+type ProviderFactory interface {
+	Create(name string, params map[string]interface{}) (framework.ServiceProvider, error)
+}
+
+// ProviderFactoryFunc 让普通函数可以当作 ProviderFactory 使用
+type ProviderFactoryFunc func(name string, params map[string]interface{}) (framework.ServiceProvider, error)
+
+// Create 实现 ProviderFactory 接口
+func (f ProviderFactoryFunc) Create(name string, params map[string]interface{}) (framework.ServiceProvider, error) {
+	return f(name, params)
+}
+
+// FactoryRegistry 保存配置 section 前缀（如 "db"、"redis"、"log"）到 ProviderFactory 的映射
+type FactoryRegistry struct {
+	factories map[string]ProviderFactory
+}
+
+// NewFactoryRegistry 创建一个空的 FactoryRegistry
+func NewFactoryRegistry() *FactoryRegistry {
+	return &FactoryRegistry{factories: map[string]ProviderFactory{}}
+}
+
+// Register 注册一个 section 前缀对应的 ProviderFactory
+func (r *FactoryRegistry) Register(section string, factory ProviderFactory) {
+	r.factories[section] = factory
+}
+
+func (r *FactoryRegistry) find(section string) (ProviderFactory, bool) {
+	factory, ok := r.factories[section]
+	return factory, ok
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/JoeZhao1/go-start/framework"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// rebinder 是 ContainerLoader 热更新配置时需要的容器能力，StartContainer 天然实现了它
+type rebinder interface {
+	framework.Container
+	Rebind(provider framework.ServiceProvider) error
+	Unbind(key string) error
+}
+
+// ContainerLoader 读取一个 TOML/YAML 配置文件，把其中每个 section（如 [db.master]）
+// 翻译成一个 ServiceProvider 并绑定到容器，同时支持监听文件变化做热更新。
+// 用法是先把 *Provider 本身绑定到容器（container.Bind(&Provider{Loader: loader})），
+// 再显式调用一次 loader.Load() 去绑定各个 section ——不要指望 Provider.Boot 去做这件事，
+// 此时 container 正持有写锁，在其中再次 Bind 会死锁
+type ContainerLoader struct {
+	path      string
+	container rebinder
+	factories *FactoryRegistry
+	watcher   *fsnotify.Watcher
+
+	// loadedMu 保护 loaded
+	loadedMu sync.Mutex
+	// loaded 记录上一次成功加载时每个 section 解析出来的参数，reload 用它来判断
+	// 一个 section 相比上一次有没有变化，只对发生了变化的 section 做 Rebind
+	loaded map[string]map[string]interface{}
+}
+
+// NewContainerLoader 创建一个 ContainerLoader，path 是配置文件路径，
+// 支持 .toml/.yaml/.yml 后缀，factories 按 section 前缀声明如何构造对应的 ServiceProvider
+func NewContainerLoader(path string, container rebinder, factories *FactoryRegistry) *ContainerLoader {
+	return &ContainerLoader{path: path, container: container, factories: factories}
+}
+
+// Load 读取一次配置文件，把每个认识的 section 绑定到容器
+func (l *ContainerLoader) Load() error {
+	sections, err := parseSections(l.path)
+	if err != nil {
+		return err
+	}
+	if err := l.bindSections(sections, l.container.Bind); err != nil {
+		return err
+	}
+
+	l.loadedMu.Lock()
+	l.loaded = sections
+	l.loadedMu.Unlock()
+	return nil
+}
+
+// reload 在配置文件变化之后重新读取，对比新旧两份 section 集合：参数相比上一次加载
+// 发生了变化（或者新增）的 section 用 Rebind 原子替换，上一次加载过但这次已经从配置
+// 文件里消失的 section 用 Unbind 移除并关闭。这样编辑文件里某一个 section（比如 [log]）
+// 不会把其它没有变化的 section（比如 [db.master]、[redis.master]）也重新构造一遍，
+// 而删掉一个 section（比如整段删掉 [redis.master]）也不会让它的 provider 和实例
+// （数据库连接池、Redis 客户端等）继续留在容器里泄漏下去
+func (l *ContainerLoader) reload() error {
+	sections, err := parseSections(l.path)
+	if err != nil {
+		return err
+	}
+
+	l.loadedMu.Lock()
+	changed := map[string]map[string]interface{}{}
+	for name, params := range sections {
+		if !reflect.DeepEqual(l.loaded[name], params) {
+			changed[name] = params
+		}
+	}
+	removed := make([]string, 0)
+	for name := range l.loaded {
+		if _, ok := sections[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	l.loadedMu.Unlock()
+
+	if err := l.bindSections(changed, l.container.Rebind); err != nil {
+		return err
+	}
+	for _, name := range removed {
+		if err := l.container.Unbind(name); err != nil {
+			return fmt.Errorf("config: 关闭已从配置中移除的 %s 失败: %w", name, err)
+		}
+	}
+
+	l.loadedMu.Lock()
+	l.loaded = sections
+	l.loadedMu.Unlock()
+	return nil
+}
+
+func (l *ContainerLoader) bindSections(sections map[string]map[string]interface{}, bind func(framework.ServiceProvider) error) error {
+	for name, params := range sections {
+		section := name
+		if idx := strings.Index(name, "."); idx >= 0 {
+			section = name[:idx]
+		}
+
+		factory, ok := l.factories.find(section)
+		if !ok {
+			continue
+		}
+
+		provider, err := factory.Create(name, params)
+		if err != nil {
+			return fmt.Errorf("config: 构造 %s 对应的服务提供者失败: %w", name, err)
+		}
+		if err := bind(provider); err != nil {
+			return fmt.Errorf("config: 绑定 %s 失败: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Watch 监听配置文件所在目录的变化，文件被修改时自动重新加载受影响的 section。
+// 调用方应该在不再需要热更新时调用 Close 停止监听
+func (l *ContainerLoader) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("config: 创建文件监听器失败: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(l.path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("config: 监听 %s 失败: %w", l.path, err)
+	}
+	l.watcher = watcher
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(l.path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if err := l.reload(); err != nil {
+					fmt.Println(err)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				fmt.Println(fmt.Errorf("config: 监听 %s 时出错: %w", l.path, err))
+			}
+		}
+	}()
+	return nil
+}
+
+// Close 停止对配置文件的监听
+func (l *ContainerLoader) Close() error {
+	if l.watcher == nil {
+		return nil
+	}
+	return l.watcher.Close()
+}
+
+// parseSections 按文件后缀解析配置文件，把嵌套的表展开成用 "." 连接的 section 名，
+// 比如 [db.master] 展开成 "db.master"，不带子表的顶层表（如 [log]）本身就是一个 section
+func parseSections(path string) (map[string]map[string]interface{}, error) {
+	raw := map[string]interface{}{}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".toml":
+		if _, err := toml.DecodeFile(path, &raw); err != nil {
+			return nil, fmt.Errorf("config: 解析 %s 失败: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: 读取 %s 失败: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("config: 解析 %s 失败: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("config: 不支持的配置文件格式 %s", ext)
+	}
+
+	sections := map[string]map[string]interface{}{}
+	flattenSections("", raw, sections)
+	return sections, nil
+}
+
+// flattenSections 递归展开嵌套表：只要一个 key 的值本身还是一张表，就继续往下展开一层；
+// 一张表里的标量值合起来就是一个 section，用展开路径作为它的名字（同时含有子表的表，
+// 它的标量值也会成为一个 section）。顶层的标量值不属于任何 section，会被忽略
+func flattenSections(prefix string, raw map[string]interface{}, out map[string]map[string]interface{}) {
+	leaf := map[string]interface{}{}
+	hasLeaf := false
+
+	for key, value := range raw {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := asStringMap(value); ok {
+			flattenSections(name, nested, out)
+			continue
+		}
+		leaf[key] = value
+		hasLeaf = true
+	}
+
+	if hasLeaf && prefix != "" {
+		out[prefix] = leaf
+	}
+}
+
+// asStringMap 把 TOML/YAML 解析出来的任意一种 map 类型统一成 map[string]interface{}
+func asStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			converted[fmt.Sprint(k)] = val
+		}
+		return converted, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import "github.com/JoeZhao1/go-start/framework"
+
+// Provider 把 ContainerLoader 自身绑定到容器里，关键字是 "config"，方便其它模块
+// 在运行时查询配置来源，或者在需要的时候手动触发一次重新加载
+type Provider struct {
+	Loader *ContainerLoader
+}
+
+func (p *Provider) Name() string {
+	return "config"
+}
+
+func (p *Provider) IsDefer() bool {
+	return false
+}
+
+func (p *Provider) Params(container framework.Container) []interface{} {
+	return nil
+}
+
+// Boot 不做任何事情：Loader.Load() 会调用 container.Bind() 给每个 section 绑定服务提供者，
+// 而 Boot 本身是在 StartContainer.Bind 持有写锁的时候被调用的，在这里调用 Load 会在同一个
+// 容器上递归加锁导致死锁。因此加载时机交给调用方：绑定完这个 Provider 之后，自行调用一次
+// Loader.Load()（见 Loader 的文档注释）
+func (p *Provider) Boot(container framework.Container) error {
+	return nil
+}
+
+func (p *Provider) Register(container framework.Container) framework.NewInstance {
+	return func(params ...interface{}) (interface{}, error) {
+		return p.Loader, nil
+	}
+}
@@ -0,0 +1,15 @@
+package console
+
+import "github.com/JoeZhao1/go-start/framework"
+
+// Command 是一个可以被 Console 调度执行的子命令
+type Command interface {
+	// Name 返回这个子命令的名字，即命令行中跟在程序名后面的第一个参数
+	Name() string
+	// Description 返回这个子命令的简短说明，被内置的 list 命令使用
+	Description() string
+	// Flags 返回这个子命令支持的命令行参数说明；Console 和内置的 list 命令都不读取它
+	Flags() []string
+	// Run 执行这个子命令，container 是应用的根容器，args 是去掉子命令名之后剩余的参数
+	Run(container framework.Container, args []string) error
+}
@@ -0,0 +1,40 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/JoeZhao1/go-start/framework"
+	"github.com/robfig/cron/v3"
+)
+
+// CronProvider 由需要跑定时任务的模块实现，在 Schedule 里用 cron 表达式
+// 把自己的任务注册到传入的调度器上
+type CronProvider interface {
+	Schedule(container framework.Container, scheduler *cron.Cron) error
+}
+
+// cronCommand 是内置的 cron 子命令：启动一个 robfig/cron 调度器并常驻运行，
+// 定时任务由所有注册进来的 CronProvider 提供
+type cronCommand struct {
+	providers []CronProvider
+}
+
+// newCronCommand 创建 cron 子命令，providers 是所有声明了定时任务的模块
+func newCronCommand(providers []CronProvider) *cronCommand {
+	return &cronCommand{providers: providers}
+}
+
+func (*cronCommand) Name() string        { return "cron" }
+func (*cronCommand) Description() string { return "启动定时任务调度器并常驻运行" }
+func (*cronCommand) Flags() []string     { return nil }
+
+func (cc *cronCommand) Run(container framework.Container, args []string) error {
+	scheduler := cron.New()
+	for _, provider := range cc.providers {
+		if err := provider.Schedule(container, scheduler); err != nil {
+			return fmt.Errorf("console: 注册定时任务失败: %w", err)
+		}
+	}
+	scheduler.Run()
+	return nil
+}
@@ -0,0 +1,37 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/JoeZhao1/go-start/framework"
+)
+
+// JobFunc 是一个一次性任务的执行体
+type JobFunc func(container framework.Container) error
+
+// jobCommand 是内置的 job 子命令：按名字执行一个注册过的一次性任务
+type jobCommand struct {
+	jobs map[string]JobFunc
+}
+
+// newJobCommand 创建 job 子命令，jobs 是任务名到任务执行体的映射
+func newJobCommand(jobs map[string]JobFunc) *jobCommand {
+	return &jobCommand{jobs: jobs}
+}
+
+func (*jobCommand) Name() string        { return "job" }
+func (*jobCommand) Description() string { return "执行一个一次性任务：job <任务名>" }
+func (*jobCommand) Flags() []string {
+	return []string{"job <任务名>：按名字执行一个已注册的任务"}
+}
+
+func (j *jobCommand) Run(container framework.Container, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("console: job 命令需要指定任务名")
+	}
+	fn, ok := j.jobs[args[0]]
+	if !ok {
+		return fmt.Errorf("console: 任务 %s 未注册", args[0])
+	}
+	return fn(container)
+}
@@ -0,0 +1,36 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/JoeZhao1/go-start/framework"
+)
+
+// listCommand 是内置的 list 子命令：输出容器中注册的所有服务提供者
+type listCommand struct {
+	registry *CommandRegistry
+}
+
+func (*listCommand) Name() string { return "list" }
+func (*listCommand) Description() string {
+	return "列出容器中注册的所有服务提供者和子命令"
+}
+func (*listCommand) Flags() []string { return nil }
+
+func (l *listCommand) Run(container framework.Container, args []string) error {
+	start, ok := container.(interface{ PrintProviders() []string })
+	if !ok {
+		return fmt.Errorf("console: list 命令需要一个实现了 PrintProviders 的容器")
+	}
+
+	fmt.Println("providers:")
+	for _, line := range start.PrintProviders() {
+		fmt.Println("  " + line)
+	}
+
+	fmt.Println("commands:")
+	for _, cmd := range l.registry.All() {
+		fmt.Printf("  %s\t%s\n", cmd.Name(), cmd.Description())
+	}
+	return nil
+}
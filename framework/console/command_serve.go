@@ -0,0 +1,43 @@
+package console
+
+import (
+	"flag"
+
+	"github.com/JoeZhao1/go-start/framework"
+	"github.com/JoeZhao1/go-start/framework/gin"
+)
+
+// RouteRegister 由业务层实现，负责把路由挂载到 gin.Engine 上，通常就是 app/http.Routes
+type RouteRegister func(r *gin.Engine, container framework.Container)
+
+// serveCommand 是内置的 serve 子命令：启动 HTTP 服务
+type serveCommand struct {
+	registerRoutes RouteRegister
+	addr           string
+}
+
+// newServeCommand 创建 serve 子命令，registerRoutes 通常是 app/http.Routes，addr 是监听地址
+func newServeCommand(registerRoutes RouteRegister, addr string) *serveCommand {
+	return &serveCommand{registerRoutes: registerRoutes, addr: addr}
+}
+
+func (*serveCommand) Name() string        { return "serve" }
+func (*serveCommand) Description() string { return "启动 HTTP 服务" }
+func (*serveCommand) Flags() []string     { return []string{"-addr：监听地址，默认 :8080"} }
+
+func (s *serveCommand) Run(container framework.Container, args []string) error {
+	addr := s.addr
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.StringVar(&addr, "addr", addr, "监听地址")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	r := gin.Default()
+	s.registerRoutes(r, container)
+	return r.Run(addr)
+}
@@ -0,0 +1,80 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/JoeZhao1/go-start/framework"
+)
+
+// shutdownTimeout 是进程收到退出信号后，等待容器 Shutdown 完成的最长时间
+const shutdownTimeout = 10 * time.Second
+
+// bootTimeout 是调度子命令之前，等待容器按依赖顺序 Boot 完成的最长时间
+const bootTimeout = 10 * time.Second
+
+// Console 把服务容器包装成一个多模式的命令行入口，类似 `-a api|cron|job` 的用法：
+// api 模式对应 serve 子命令，cron/job 模式分别对应同名子命令
+type Console struct {
+	container framework.Container
+	registry  *CommandRegistry
+}
+
+// NewConsole 创建一个 Console，registry 为 nil 时使用一个空的注册表
+func NewConsole(container framework.Container, registry *CommandRegistry) *Console {
+	if registry == nil {
+		registry = NewCommandRegistry()
+	}
+	return &Console{container: container, registry: registry}
+}
+
+// Registry 返回这个 Console 使用的命令注册表，业务代码可以用它注册自己的子命令
+func (c *Console) Registry() *CommandRegistry {
+	return c.registry
+}
+
+// Run 是 main.go 的统一入口：args[0] 是程序名，args[1] 是子命令名，其余部分原样
+// 传递给子命令。调度子命令之前会先按依赖顺序 Boot 容器中的服务提供者；进程收到
+// SIGINT/SIGTERM 时会调用容器的 Shutdown 做优雅退出
+func (c *Console) Run(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("console: 请指定一个子命令，可以用 list 查看所有已注册的子命令")
+	}
+
+	cmd, err := c.registry.Find(args[1])
+	if err != nil {
+		return err
+	}
+
+	if lifecycle, ok := c.container.(interface{ Boot(context.Context) error }); ok {
+		bootCtx, cancel := context.WithTimeout(context.Background(), bootTimeout)
+		err := lifecycle.Boot(bootCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Run(c.container, args[2:]) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-sigCh:
+		lifecycle, ok := c.container.(interface{ Shutdown(context.Context) error })
+		if !ok {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return lifecycle.Shutdown(ctx)
+	}
+}
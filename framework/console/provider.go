@@ -0,0 +1,44 @@
+package console
+
+import "github.com/JoeZhao1/go-start/framework"
+
+// Provider 是 console 子系统的服务提供者，绑定到容器的关键字是 "console"，
+// 绑定完成后可以通过 container.MustMake("console").(*Console) 取出使用
+type Provider struct {
+	// Registry 是这个 Console 使用的命令注册表，可以通过 NewDefaultRegistry 构造
+	Registry *CommandRegistry
+}
+
+func (p *Provider) Name() string {
+	return "console"
+}
+
+func (p *Provider) IsDefer() bool {
+	return false
+}
+
+func (p *Provider) Params(container framework.Container) []interface{} {
+	return []interface{}{container}
+}
+
+func (p *Provider) Boot(container framework.Container) error {
+	return nil
+}
+
+func (p *Provider) Register(container framework.Container) framework.NewInstance {
+	return func(params ...interface{}) (interface{}, error) {
+		c := params[0].(framework.Container)
+		return NewConsole(c, p.Registry), nil
+	}
+}
+
+// NewDefaultRegistry 构造一个注册了全部内置子命令（serve/cron/job/list）的命令注册表。
+// registerRoutes 通常传 app/http.Routes，addr 为空时 serve 默认监听 :8080
+func NewDefaultRegistry(registerRoutes RouteRegister, addr string, jobs map[string]JobFunc, crons []CronProvider) *CommandRegistry {
+	registry := NewCommandRegistry()
+	registry.Register(newServeCommand(registerRoutes, addr))
+	registry.Register(newCronCommand(crons))
+	registry.Register(newJobCommand(jobs))
+	registry.Register(&listCommand{registry: registry})
+	return registry
+}
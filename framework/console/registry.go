@@ -0,0 +1,36 @@
+package console
+
+import "fmt"
+
+// CommandRegistry 保存所有注册到 Console 的子命令
+type CommandRegistry struct {
+	commands map[string]Command
+}
+
+// NewCommandRegistry 创建一个空的命令注册表
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{commands: map[string]Command{}}
+}
+
+// Register 注册一个子命令，如果名字已经存在则会被覆盖
+func (r *CommandRegistry) Register(cmd Command) {
+	r.commands[cmd.Name()] = cmd
+}
+
+// Find 根据名字查找一个子命令
+func (r *CommandRegistry) Find(name string) (Command, error) {
+	cmd, ok := r.commands[name]
+	if !ok {
+		return nil, fmt.Errorf("console: 子命令 %s 未注册", name)
+	}
+	return cmd, nil
+}
+
+// All 返回所有已注册的子命令，按注册时的名字排序并不保证，仅用于 list 命令展示
+func (r *CommandRegistry) All() []Command {
+	ret := make([]Command, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		ret = append(ret, cmd)
+	}
+	return ret
+}
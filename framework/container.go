@@ -3,6 +3,7 @@ package framework
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 )
 
@@ -22,6 +23,12 @@ type Container interface {
 	// 它是根据服务提供者注册的启动函数和传递的params参数实例化出来的
 	// 这个函数在需要为不同参数启动不同实例的时候非常有用
 	MakeNew(key string, params []interface{}) (interface{}, error)
+
+	// NewScope 创建一个子容器：查找服务时会在当前容器找不到的情况下回退到父容器，
+	// 但绑定（Bind/Provide）只会作用于子容器自身，不会影响父容器
+	NewScope() Container
+	// Release 释放这个容器已经实例化的所有实例：对实现了 io.Closer 的实例调用 Close()
+	Release() error
 }
 
 // StartContainer 是服务容器的具体实现
@@ -31,6 +38,13 @@ type StartContainer struct {
 	providers map[string]ServiceProvider
 	// instance 存储具体的实例，key为字符串凭证
 	instances map[string]interface{}
+	// typeBindings 存储通过 Provide/Singleton/Prototype 注册的构造函数，key为构造函数的返回类型
+	typeBindings map[reflect.Type]*typeBinding
+	// parent 是这个容器的父容器，仅在通过 NewScope 创建子容器时被设置，
+	// 查找服务时，如果当前容器没有找到，会继续在 parent 中查找
+	parent *StartContainer
+	// rebindSubs 存储通过 OnRebind 订阅的回调，key 为凭证字符串
+	rebindSubs map[string][]RebindHandler
 	// lock 用于锁住对容器的变更操作
 	lock sync.RWMutex
 }
@@ -66,8 +80,13 @@ func (start *StartContainer) Bind(provider ServiceProvider) error {
 
 	// if provider is not defer
 	if provider.IsDefer() == false {
-		if err := provider.Boot(start); err != nil {
-			return err
+		// LifecycleProvider 的 Boot 会按照 DependsOn 声明的依赖顺序由显式的
+		// StartContainer.Boot(ctx) 调用，这里立即调用会在依赖的服务提供者还没注册时
+		// 就把它启动了，破坏声明的启动顺序，所以交给 Boot(ctx) 延后处理
+		if _, ok := provider.(LifecycleProvider); !ok {
+			if err := provider.Boot(start); err != nil {
+				return err
+			}
 		}
 		// 实例化方法
 		params := provider.Params(start)
@@ -87,10 +106,16 @@ func (start *StartContainer) IsBind(key string) bool {
 
 func (start *StartContainer) findServiceProvider(key string) ServiceProvider {
 	start.lock.RLock()
-	defer start.lock.RUnlock()
-	if sp, ok := start.providers[key]; ok {
+	sp, ok := start.providers[key]
+	parent := start.parent
+	start.lock.RUnlock()
+
+	if ok {
 		return sp
 	}
+	if parent != nil {
+		return parent.findServiceProvider(key)
+	}
 	return nil
 }
 
@@ -129,10 +154,19 @@ func (start *StartContainer) newInstance(sp ServiceProvider, params []interface{
 // 真正的实例化一个服务
 func (start *StartContainer) make(key string, params []interface{}, forceNew bool) (interface{}, error) {
 	start.lock.RLock()
-	defer start.lock.RUnlock()
-	// 查询是否已经注册了这个服务提供者，如果没有注册，则返回错误
-	sp := start.findServiceProvider(key)
-	if sp == nil {
+	sp, localOk := start.providers[key]
+	parent := start.parent
+	start.lock.RUnlock()
+
+	// 当前容器没有注册这个凭证：如果是子容器，交给父容器处理（实现 NewScope 的继承查找）；
+	// 否则尝试从类型注册表中查找同名类型兜底
+	if !localOk {
+		if parent != nil {
+			return parent.make(key, params, forceNew)
+		}
+		if ins, err := start.makeByTypeName(key); err == nil {
+			return ins, nil
+		}
 		return nil, errors.New("contract " + key + " have not register")
 	}
 
@@ -141,7 +175,10 @@ func (start *StartContainer) make(key string, params []interface{}, forceNew boo
 	}
 
 	// 不需要强制重新实例化，如果容器中已经实例化了，那么就直接使用容器中的实例
-	if ins, ok := start.instances[key]; ok {
+	start.lock.RLock()
+	ins, ok := start.instances[key]
+	start.lock.RUnlock()
+	if ok {
 		return ins, nil
 	}
 
@@ -151,6 +188,8 @@ func (start *StartContainer) make(key string, params []interface{}, forceNew boo
 		return nil, err
 	}
 
+	start.lock.Lock()
 	start.instances[key] = inst
+	start.lock.Unlock()
 	return inst, nil
 }
@@ -0,0 +1,18 @@
+package framework
+
+import "github.com/JoeZhao1/go-start/framework/gin"
+
+// ScopeContextKey 是请求级 Container 挂载到 gin.Context 时使用的 key，
+// 由 app/http/middleware/scope 中间件负责写入
+const ScopeContextKey = "framework:container"
+
+// FromContext 从 gin.Context 中取出这次请求绑定的容器，通常是 scope 中间件
+// 通过 NewScope 创建的子容器。如果没有绑定过（比如没有使用 scope 中间件），返回 nil
+func FromContext(c *gin.Context) Container {
+	ins, ok := c.Get(ScopeContextKey)
+	if !ok {
+		return nil
+	}
+	container, _ := ins.(Container)
+	return container
+}
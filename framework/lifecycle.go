@@ -0,0 +1,135 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LifecycleProvider 是一个可选接口，ServiceProvider 额外实现它之后即可参与
+// StartContainer 的有序启动（Boot）和有序关闭（Shutdown）
+type LifecycleProvider interface {
+	ServiceProvider
+	// Shutdown 在容器关闭时被调用，用于释放这个服务提供者持有的资源（数据库连接、Redis 客户端等）
+	Shutdown(Container) error
+	// DependsOn 声明这个服务提供者依赖的其它服务提供者的 Name()，Boot 会按照依赖关系排好序再调用
+	DependsOn() []string
+}
+
+// defaultShutdownTimeout 是单个服务提供者关闭时默认使用的超时时间
+const defaultShutdownTimeout = 5 * time.Second
+
+// Boot 按照 LifecycleProvider 声明的依赖关系对已注册的服务提供者做拓扑排序，
+// 然后依次调用它们的 Boot 方法。非 LifecycleProvider 的服务提供者视为没有依赖，
+// 只保证排在依赖它的服务提供者之前，和其它服务提供者之间的相对顺序不做保证
+func (start *StartContainer) Boot(ctx context.Context) error {
+	start.lock.RLock()
+	providers := make(map[string]ServiceProvider, len(start.providers))
+	for key, sp := range start.providers {
+		providers[key] = sp
+	}
+	start.lock.RUnlock()
+
+	order, err := topoSortProviders(providers)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range order {
+		sp := providers[key]
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := sp.Boot(start); err != nil {
+			return fmt.Errorf("framework: 服务提供者 %s 启动失败: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Shutdown 按照 Boot 顺序的逆序依次关闭实现了 LifecycleProvider 的服务提供者，
+// 每个服务提供者的关闭都有一个独立的超时时间
+func (start *StartContainer) Shutdown(ctx context.Context) error {
+	start.lock.RLock()
+	providers := make(map[string]ServiceProvider, len(start.providers))
+	for key, sp := range start.providers {
+		providers[key] = sp
+	}
+	start.lock.RUnlock()
+
+	order, err := topoSortProviders(providers)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for i := len(order) - 1; i >= 0; i-- {
+		lp, ok := providers[order[i]].(LifecycleProvider)
+		if !ok {
+			continue
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(ctx, defaultShutdownTimeout)
+		errCh := make(chan error, 1)
+		go func() { errCh <- lp.Shutdown(start) }()
+
+		select {
+		case err := <-errCh:
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("framework: 服务提供者 %s 关闭失败: %w", order[i], err)
+			}
+		case <-shutdownCtx.Done():
+			if firstErr == nil {
+				firstErr = fmt.Errorf("framework: 服务提供者 %s 关闭超时", order[i])
+			}
+		}
+		cancel()
+	}
+	return firstErr
+}
+
+// topoSortProviders 根据 LifecycleProvider.DependsOn 声明的依赖关系对服务提供者做拓扑排序，
+// 被依赖的服务提供者排在依赖它的服务提供者之前；不是 LifecycleProvider 的服务提供者视为没有依赖
+func topoSortProviders(providers map[string]ServiceProvider) ([]string, error) {
+	const (
+		white = iota
+		gray
+		black
+	)
+	state := make(map[string]int, len(providers))
+	order := make([]string, 0, len(providers))
+
+	var visit func(key string, path []string) error
+	visit = func(key string, path []string) error {
+		switch state[key] {
+		case black:
+			return nil
+		case gray:
+			return fmt.Errorf("framework: 服务提供者之间存在循环依赖: %v", append(path, key))
+		}
+
+		state[key] = gray
+		if lp, ok := providers[key].(LifecycleProvider); ok {
+			for _, dep := range lp.DependsOn() {
+				if _, registered := providers[dep]; !registered {
+					return fmt.Errorf("framework: 服务提供者 %s 依赖了未注册的 %s", key, dep)
+				}
+				if err := visit(dep, append(path, key)); err != nil {
+					return err
+				}
+			}
+		}
+		state[key] = black
+		order = append(order, key)
+		return nil
+	}
+
+	for key := range providers {
+		if err := visit(key, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
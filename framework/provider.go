@@ -0,0 +1,18 @@
+package framework
+
+// NewInstance 是服务实例化方法，接受服务提供者声明的参数，返回实例和可能的错误
+type NewInstance func(params ...interface{}) (interface{}, error)
+
+// ServiceProvider 是服务提供者，所有绑定到服务容器的服务都需要实现这个接口
+type ServiceProvider interface {
+	// Register 在服务容器中注册方法, 这个方法会在 Bind 的时候执行一次
+	Register(Container) NewInstance
+	// Boot 在注册服务的时候会调用，可以用于初始化前置条件
+	Boot(Container) error
+	// IsDefer 代表是否延迟加载，如果为 true，则在 Bind 的时候不会实例化，等到第一次 Make 的时候才会实例化
+	IsDefer() bool
+	// Params 获取这个服务实例化所需要的参数
+	Params(Container) []interface{}
+	// Name 获取这个服务提供者的唯一凭证字符串，也是绑定到服务容器中的关键字
+	Name() string
+}
@@ -0,0 +1,83 @@
+package framework
+
+import "io"
+
+// RebindHandler 在某个凭证对应的实例被热更新替换之后被调用，newInstance 是替换后的新实例
+type RebindHandler func(newInstance interface{})
+
+// OnRebind 订阅某个凭证的热更新事件：每次通过 Rebind 替换这个凭证的实例时都会调用 handler。
+// 用于让已经持有旧实例缓存引用的消费者（比如配置热更新场景）有机会刷新自己手里的引用
+func (start *StartContainer) OnRebind(key string, handler RebindHandler) {
+	start.lock.Lock()
+	defer start.lock.Unlock()
+	if start.rebindSubs == nil {
+		start.rebindSubs = map[string][]RebindHandler{}
+	}
+	start.rebindSubs[key] = append(start.rebindSubs[key], handler)
+}
+
+// Rebind 原子地用 provider 重新实例化并替换 key 对应的服务提供者和实例，
+// 替换完成后通知所有通过 OnRebind 订阅了这个凭证的回调，再关闭被替换下来的旧实例
+// （旧的服务提供者实现了 LifecycleProvider 则调用其 Shutdown，否则退化为 io.Closer.Close()），
+// 避免数据库连接池、Redis 客户端之类的资源在热更新时被静默泄漏。与 Bind 不同，
+// Rebind 总是立即重新实例化，不考虑 IsDefer()
+func (start *StartContainer) Rebind(provider ServiceProvider) error {
+	key := provider.Name()
+
+	if err := provider.Boot(start); err != nil {
+		return err
+	}
+	params := provider.Params(start)
+	method := provider.Register(start)
+	instance, err := method(params...)
+	if err != nil {
+		return err
+	}
+
+	start.lock.Lock()
+	oldProvider, hadOld := start.providers[key]
+	oldInstance := start.instances[key]
+	start.providers[key] = provider
+	start.instances[key] = instance
+	handlers := append([]RebindHandler{}, start.rebindSubs[key]...)
+	start.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler(instance)
+	}
+
+	if hadOld {
+		return closeOldInstance(start, oldProvider, oldInstance)
+	}
+	return nil
+}
+
+// Unbind 从容器中移除 key 对应的服务提供者和实例，并关闭被移除的实例（旧的服务提供者
+// 实现了 LifecycleProvider 则调用其 Shutdown，否则退化为 io.Closer.Close()）。
+// 用于配置热更新场景：当配置文件里的一个 section 被删除时，用它来清理这个 section
+// 绑定的资源，而不是让失去配置来源的 provider 和它的实例继续留在容器里
+func (start *StartContainer) Unbind(key string) error {
+	start.lock.Lock()
+	oldProvider, hadOld := start.providers[key]
+	oldInstance := start.instances[key]
+	delete(start.providers, key)
+	delete(start.instances, key)
+	start.lock.Unlock()
+
+	if !hadOld {
+		return nil
+	}
+	return closeOldInstance(start, oldProvider, oldInstance)
+}
+
+// closeOldInstance 关闭热更新替换下来的旧实例：优先调用旧的 ServiceProvider（如果实现了
+// LifecycleProvider）的 Shutdown，否则退化为对旧实例调用 io.Closer.Close()
+func closeOldInstance(container Container, oldProvider ServiceProvider, oldInstance interface{}) error {
+	if lp, ok := oldProvider.(LifecycleProvider); ok {
+		return lp.Shutdown(container)
+	}
+	if closer, ok := oldInstance.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
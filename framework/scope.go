@@ -0,0 +1,33 @@
+package framework
+
+import "io"
+
+// NewScope 创建一个子容器：查找服务时会在当前容器找不到的情况下回退到父容器，
+// 但绑定（Bind/Provide）只会作用于子容器自身，不会影响父容器。
+// 典型用法是为每一个 HTTP 请求创建一个 scope，用来持有请求级别的实例（如携带 trace-id 的 logger）
+func (start *StartContainer) NewScope() Container {
+	return &StartContainer{
+		parent:    start,
+		providers: map[string]ServiceProvider{},
+		instances: map[string]interface{}{},
+	}
+}
+
+// Release 释放这个容器已经实例化的所有实例：对实现了 io.Closer 的实例调用 Close()。
+// 通常在请求结束时调用，用于回收请求级别的资源（数据库连接、临时文件句柄等）
+func (start *StartContainer) Release() error {
+	start.lock.Lock()
+	instances := start.instances
+	start.instances = map[string]interface{}{}
+	start.lock.Unlock()
+
+	var firstErr error
+	for _, ins := range instances {
+		if closer, ok := ins.(io.Closer); ok {
+			if err := closer.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
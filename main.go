@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/JoeZhao1/go-start/app/http"
+	"github.com/JoeZhao1/go-start/framework"
+	"github.com/JoeZhao1/go-start/framework/console"
+)
+
+func main() {
+	container := framework.NewStartContainer()
+
+	registry := console.NewDefaultRegistry(http.Routes, "", nil, nil)
+	if err := container.Bind(&console.Provider{Registry: registry}); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := container.MustMake("console").(*console.Console).Run(os.Args); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}